@@ -0,0 +1,386 @@
+package lru
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/wzshiming/lru/internal/container/list"
+	syncmap "github.com/wzshiming/lru/internal/sync"
+)
+
+// arcWhere records which of the four ARC lists currently holds a key.
+type arcWhere uint8
+
+const (
+	arcT1 arcWhere = iota
+	arcT2
+	arcB1
+	arcB2
+)
+
+// arcRef is the value stored in the items map for a key. Depending on
+// where the key currently lives, either elem (T1/T2, carries a value) or
+// ghost (B1/B2, key only) is populated.
+type arcRef[K comparable, V any] struct {
+	where arcWhere
+	elem  *list.Element[entries[K, V]]
+	ghost *list.Element[K]
+}
+
+// ARC is a thread-safe fixed size Adaptive Replacement Cache, as described
+// by Megiddo and Modha. It keeps two lists of cached entries, T1 (seen
+// once) and T2 (seen at least twice), plus two ghost lists B1/B2 that
+// remember the keys of recently evicted entries so the cache can adapt
+// its target size p between recency and frequency.
+//
+// ARC's bookkeeping (list membership plus the adaptation of p) must be
+// applied atomically per request, so it serializes every mutating
+// operation, Get included, behind a single mutex.
+type ARC[K comparable, V any] struct {
+	mut sync.Mutex
+
+	size uint64 // maximum number of items in cache
+	p    uint64 // target size for T1
+
+	items syncmap.Map[K, *arcRef[K, V]]
+
+	t1, t2 *linked[entries[K, V]]
+	b1, b2 *linked[K]
+
+	evicted func(K, V)
+}
+
+// NewARC returns a new ARC of the given size.
+func NewARC[K comparable, V any](size int, evicted func(K, V)) *ARC[K, V] {
+	return &ARC[K, V]{
+		size:    uint64(size),
+		t1:      newLinked[entries[K, V]](),
+		t2:      newLinked[entries[K, V]](),
+		b1:      newLinked[K](),
+		b2:      newLinked[K](),
+		evicted: evicted,
+	}
+}
+
+// Len returns the length of the arc cache (entries with a value, T1+T2).
+func (a *ARC[K, V]) Len() int {
+	return a.t1.Len() + a.t2.Len()
+}
+
+// Cap returns the capacity of the arc cache
+func (a *ARC[K, V]) Cap() int {
+	return int(atomic.LoadUint64(&a.size))
+}
+
+// Resize resizes the cache to the specified size.
+func (a *ARC[K, V]) Resize(size int) {
+	a.mut.Lock()
+	defer a.mut.Unlock()
+	atomic.StoreUint64(&a.size, uint64(size))
+	for a.t1.Len()+a.t2.Len() > int(a.size) {
+		a.replace(false)
+	}
+	a.trimGhosts()
+}
+
+// Get returns a value for key and mark it as most recently used.
+func (a *ARC[K, V]) Get(key K) (value V, ok bool) {
+	a.mut.Lock()
+	defer a.mut.Unlock()
+
+	ref, ok := a.items.Load(key)
+	if !ok || ref == nil {
+		return value, false
+	}
+
+	switch ref.where {
+	case arcT1:
+		item := a.t1.Remove(ref.elem)
+		_, value = item.get()
+		ref.elem = a.t2.PushBack(entries[K, V]{key: key, value: value})
+		ref.where = arcT2
+		return value, true
+	case arcT2:
+		a.t2.MoveToBack(ref.elem)
+		_, value = ref.elem.Value.get()
+		return value, true
+	default:
+		return value, false
+	}
+}
+
+// Peek returns the value for key without marking it as most recently used.
+func (a *ARC[K, V]) Peek(key K) (value V, ok bool) {
+	a.mut.Lock()
+	defer a.mut.Unlock()
+
+	ref, ok := a.items.Load(key)
+	if !ok || ref == nil || ref.elem == nil {
+		return value, false
+	}
+	_, value = ref.elem.Value.get()
+	return value, true
+}
+
+// Contains returns true if the key exists.
+func (a *ARC[K, V]) Contains(key K) bool {
+	a.mut.Lock()
+	defer a.mut.Unlock()
+
+	ref, ok := a.items.Load(key)
+	return ok && ref != nil && ref.elem != nil
+}
+
+// Put sets the value for the specified key.
+func (a *ARC[K, V]) Put(key K, value V) (prev V, replaced bool) {
+	a.mut.Lock()
+	defer a.mut.Unlock()
+
+	c := int(a.size)
+
+	if ref, ok := a.items.Load(key); ok && ref != nil {
+		switch ref.where {
+		case arcT1:
+			item := a.t1.Remove(ref.elem)
+			_, prev = item.get()
+			ref.elem = a.t2.PushBack(entries[K, V]{key: key, value: value})
+			ref.where = arcT2
+			return prev, true
+		case arcT2:
+			prev = ref.elem.Value.set(value)
+			a.t2.MoveToBack(ref.elem)
+			return prev, true
+		case arcB1:
+			d := maxInt(a.b2.Len()/maxInt(a.b1.Len(), 1), 1)
+			a.p = minUint64(a.p+uint64(d), uint64(c))
+			a.b1.Remove(ref.ghost)
+			a.replace(false)
+			ref.ghost = nil
+			ref.elem = a.t2.PushBack(entries[K, V]{key: key, value: value})
+			ref.where = arcT2
+			return prev, false
+		case arcB2:
+			d := maxInt(a.b1.Len()/maxInt(a.b2.Len(), 1), 1)
+			if uint64(d) > a.p {
+				a.p = 0
+			} else {
+				a.p -= uint64(d)
+			}
+			a.b2.Remove(ref.ghost)
+			a.replace(true)
+			ref.ghost = nil
+			ref.elem = a.t2.PushBack(entries[K, V]{key: key, value: value})
+			ref.where = arcT2
+			return prev, false
+		}
+	}
+
+	// key is in none of the four lists: a genuine cache miss.
+	t1Len, b1Len := a.t1.Len(), a.b1.Len()
+	t2Len, b2Len := a.t2.Len(), a.b2.Len()
+	switch {
+	case t1Len+b1Len == c:
+		if t1Len < c {
+			a.items.Delete(a.b1.Remove(a.b1.Front()))
+			a.replace(false)
+		} else {
+			// B1 is empty and T1 alone fills the cache: drop T1's LRU
+			// entry outright, leaving no ghost, so T1+B1 stays within c.
+			item := a.t1.Remove(a.t1.Front())
+			evKey, evValue := item.get()
+			a.items.Delete(evKey)
+			if a.evicted != nil {
+				a.evicted(evKey, evValue)
+			}
+		}
+	case t1Len+b1Len < c && t1Len+t2Len+b1Len+b2Len >= c:
+		if t1Len+t2Len+b1Len+b2Len == 2*c {
+			a.items.Delete(a.b2.Remove(a.b2.Front()))
+		}
+		a.replace(false)
+	}
+
+	elem := a.t1.PushBack(entries[K, V]{key: key, value: value})
+	a.items.Store(key, &arcRef[K, V]{where: arcT1, elem: elem})
+	return prev, false
+}
+
+// replace evicts the LRU entry of T1 or T2 into its matching ghost list,
+// following the classic ARC REPLACE rule. It falls back to the other list
+// when its preferred choice is empty: Delete can drain T1 or T2 directly
+// without adjusting p, so the preferred list being empty doesn't mean
+// there's nothing left to evict.
+func (a *ARC[K, V]) replace(inB2 bool) {
+	t1Len := a.t1.Len()
+	if t1Len > 0 && ((inB2 && uint64(t1Len) == a.p) || uint64(t1Len) > a.p) {
+		a.dropLRU(a.t1, arcT1)
+		return
+	}
+	if a.t2.Len() > 0 {
+		a.dropLRU(a.t2, arcT2)
+		return
+	}
+	if a.t1.Len() > 0 {
+		a.dropLRU(a.t1, arcT1)
+	}
+}
+
+// dropLRU removes the LRU element of lst (T1 or T2), evicts it, and
+// records its key in the matching ghost list.
+func (a *ARC[K, V]) dropLRU(lst *linked[entries[K, V]], where arcWhere) {
+	node := lst.Front()
+	if node == nil {
+		return
+	}
+	item := lst.Remove(node)
+	key, value := item.get()
+	a.items.Delete(key)
+
+	var ghost *linked[K]
+	var ghostWhere arcWhere
+	if where == arcT1 {
+		ghost, ghostWhere = a.b1, arcB1
+	} else {
+		ghost, ghostWhere = a.b2, arcB2
+	}
+	g := ghost.PushBack(key)
+	a.items.Store(key, &arcRef[K, V]{where: ghostWhere, ghost: g})
+
+	if a.evicted != nil {
+		a.evicted(key, value)
+	}
+}
+
+// trimGhosts keeps each ghost list bounded by the cache capacity.
+func (a *ARC[K, V]) trimGhosts() {
+	c := int(a.size)
+	for a.b1.Len() > c {
+		node := a.b1.Front()
+		if node == nil {
+			break
+		}
+		key := a.b1.Remove(node)
+		a.items.Delete(key)
+	}
+	for a.b2.Len() > c {
+		node := a.b2.Front()
+		if node == nil {
+			break
+		}
+		key := a.b2.Remove(node)
+		a.items.Delete(key)
+	}
+}
+
+// Evict evicts the least recently used item from the cache.
+func (a *ARC[K, V]) Evict() (key K, value V, evicted bool) {
+	a.mut.Lock()
+	defer a.mut.Unlock()
+
+	lst := a.t1
+	if lst.Len() == 0 {
+		lst = a.t2
+	}
+	node := lst.Front()
+	if node == nil {
+		return key, value, false
+	}
+	item := lst.Remove(node)
+	key, value = item.get()
+	a.items.Delete(key)
+	if a.evicted != nil {
+		a.evicted(key, value)
+	}
+	return key, value, true
+}
+
+// Delete a value for a key
+func (a *ARC[K, V]) Delete(key K) (prev V, deleted bool) {
+	a.mut.Lock()
+	defer a.mut.Unlock()
+
+	ref, ok := a.items.LoadAndDelete(key)
+	if !ok || ref == nil {
+		return prev, false
+	}
+	switch ref.where {
+	case arcT1:
+		item := a.t1.Remove(ref.elem)
+		_, prev = item.get()
+		return prev, true
+	case arcT2:
+		item := a.t2.Remove(ref.elem)
+		_, prev = item.get()
+		return prev, true
+	case arcB1:
+		a.b1.Remove(ref.ghost)
+		return prev, false
+	case arcB2:
+		a.b2.Remove(ref.ghost)
+		return prev, false
+	}
+	return prev, false
+}
+
+// ForEach iterates over the cache, calling f for each item.
+func (a *ARC[K, V]) ForEach(iter func(key K, value V) bool) {
+	ok := true
+	a.t2.ForEach(func(item *list.Element[entries[K, V]]) bool {
+		if !ok {
+			return false
+		}
+		key, value := item.Value.get()
+		ok = iter(key, value)
+		return ok
+	})
+	if !ok {
+		return
+	}
+	a.t1.ForEach(func(item *list.Element[entries[K, V]]) bool {
+		if !ok {
+			return false
+		}
+		key, value := item.Value.get()
+		ok = iter(key, value)
+		return ok
+	})
+}
+
+// Keys returns a slice of the keys in the cache.
+func (a *ARC[K, V]) Keys() []K {
+	keys := make([]K, 0, a.Len())
+	a.ForEach(func(key K, value V) bool {
+		keys = append(keys, key)
+		return true
+	})
+	return keys
+}
+
+// Values returns a slice of the values in the cache.
+func (a *ARC[K, V]) Values() []V {
+	values := make([]V, 0, a.Len())
+	a.ForEach(func(key K, value V) bool {
+		values = append(values, value)
+		return true
+	})
+	return values
+}
+
+// Close closes the cache. ARC runs no background goroutines, so this is a
+// no-op kept for Cache interface compatibility.
+func (a *ARC[K, V]) Close() {
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func minUint64(a, b uint64) uint64 {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,61 @@
+package lru
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestARC(t *testing.T) {
+	arc := NewARC[string, string](4, func(k string, v string) {
+		t.Logf("evict key: %s, value: %s", k, v)
+	})
+	defer arc.Close()
+
+	if l, c := arc.Len(), arc.Cap(); l != 0 || c != 4 {
+		t.Errorf("got len, cap = %d, %d, want 0, 4", l, c)
+	}
+
+	for i := 0; i < 5; i++ {
+		arc.Put(fmt.Sprintf("key%d", i), fmt.Sprintf("value%d", i))
+	}
+	if l := arc.Len(); l != 4 {
+		t.Errorf("got len = %d, want 4", l)
+	}
+	if arc.Contains("key0") {
+		t.Errorf("key0 should have been evicted")
+	}
+	if !arc.Contains("key4") {
+		t.Errorf("key4 should be in arc")
+	}
+
+	// a hit on key1 should promote it from T1 into T2.
+	if v, ok := arc.Get("key1"); !ok || v != "value1" {
+		t.Errorf("got get(key1) = %q, %v, want value1, true", v, ok)
+	}
+
+	// key0 was dropped outright when T1 alone filled the cache, leaving no
+	// ghost, so re-inserting it is a plain miss: nothing is replaced, and
+	// state is not corrupted.
+	prev, replaced := arc.Put("key0", "new-value0")
+	if replaced {
+		t.Errorf("got replaced = %v, want false", replaced)
+	}
+	if prev != "" {
+		t.Errorf("got prev = %q, want empty", prev)
+	}
+	if v, ok := arc.Peek("key0"); !ok || v != "new-value0" {
+		t.Errorf("got peek(key0) = %q, %v, want new-value0, true", v, ok)
+	}
+
+	if _, ok := arc.Delete("key1"); !ok {
+		t.Errorf("key1 should have been deleted")
+	}
+	if arc.Contains("key1") {
+		t.Errorf("key1 should be gone")
+	}
+
+	arc.Resize(2)
+	if l, c := arc.Len(), arc.Cap(); l > 2 || c != 2 {
+		t.Errorf("got len, cap = %d, %d, want <=2, 2", l, c)
+	}
+}
@@ -0,0 +1,212 @@
+package lru
+
+// basicNode is a node in BasicLRU's intrusive doubly-linked list. Unlike
+// the generic internal/container/list used elsewhere in this package, a
+// node here is a plain struct BasicLRU can keep around and overwrite in
+// place, which is what lets Put avoid allocating on the eviction path.
+type basicNode[K comparable, V any] struct {
+	key        K
+	value      V
+	prev, next *basicNode[K, V]
+}
+
+// BasicLRU is a fixed size LRU cache with no internal locking: it assumes
+// single-threaded use, or a caller that already holds its own lock. LRU is
+// a thin concurrent wrapper around exactly this type; reach for BasicLRU
+// directly when you don't need that wrapper's mutex, background sweeper,
+// or TTL/stats bookkeeping, e.g. when a program creates many small,
+// already-serialized caches.
+//
+// Put is allocation-free once the cache has reached capacity: eviction
+// reuses the evicted node's struct for the new entry instead of allocating
+// a fresh one.
+//
+// BasicLRU's method set satisfies Cache, but the package does not assert
+// it: Cache is meant for swapping between the package's concurrent-safe
+// policies, and asserting it here would imply a safety guarantee BasicLRU
+// doesn't make.
+type BasicLRU[K comparable, V any] struct {
+	size uint64
+
+	items map[K]*basicNode[K, V]
+	root  basicNode[K, V] // sentinel; root.next is the front (LRU end), root.prev is the back (MRU end)
+
+	evicted func(K, V)
+}
+
+// NewBasicLRU returns a new BasicLRU of the given size.
+func NewBasicLRU[K comparable, V any](size int, evicted func(K, V)) *BasicLRU[K, V] {
+	l := &BasicLRU[K, V]{
+		size:    uint64(size),
+		items:   make(map[K]*basicNode[K, V]),
+		evicted: evicted,
+	}
+	l.root.prev = &l.root
+	l.root.next = &l.root
+	return l
+}
+
+func (l *BasicLRU[K, V]) unlink(node *basicNode[K, V]) {
+	node.prev.next = node.next
+	node.next.prev = node.prev
+	node.prev, node.next = nil, nil
+}
+
+func (l *BasicLRU[K, V]) pushBack(node *basicNode[K, V]) {
+	last := l.root.prev
+	node.prev = last
+	node.next = &l.root
+	last.next = node
+	l.root.prev = node
+}
+
+func (l *BasicLRU[K, V]) moveToBack(node *basicNode[K, V]) {
+	l.unlink(node)
+	l.pushBack(node)
+}
+
+// Len returns the length of the cache.
+func (l *BasicLRU[K, V]) Len() int {
+	return len(l.items)
+}
+
+// Cap returns the capacity of the cache.
+func (l *BasicLRU[K, V]) Cap() int {
+	return int(l.size)
+}
+
+// Put sets the value for the specified key.
+func (l *BasicLRU[K, V]) Put(key K, value V) (prev V, replaced bool) {
+	if node, ok := l.items[key]; ok {
+		prev = node.value
+		node.value = value
+		l.moveToBack(node)
+		return prev, true
+	}
+
+	var node *basicNode[K, V]
+	if l.size > 0 && uint64(len(l.items)) >= l.size {
+		if front := l.root.next; front != &l.root {
+			node = front
+			l.unlink(node)
+			delete(l.items, node.key)
+			evictedKey, evictedValue := node.key, node.value
+			if l.evicted != nil {
+				l.evicted(evictedKey, evictedValue)
+			}
+		}
+	}
+	if node == nil {
+		node = &basicNode[K, V]{}
+	}
+
+	node.key, node.value = key, value
+	l.pushBack(node)
+	l.items[key] = node
+
+	// The pre-insert reuse above keeps Len()<=Cap() already in the common
+	// case without further work; this only does anything for the
+	// degenerate size<=0 cache, where there was no front node to reuse.
+	for uint64(len(l.items)) > l.size {
+		if _, _, evicted := l.Evict(); !evicted {
+			break
+		}
+	}
+	return prev, false
+}
+
+// Get returns a value for key and marks it as most recently used.
+func (l *BasicLRU[K, V]) Get(key K) (value V, ok bool) {
+	node, ok := l.items[key]
+	if !ok {
+		return value, false
+	}
+	l.moveToBack(node)
+	return node.value, true
+}
+
+// Contains returns true if the key exists.
+func (l *BasicLRU[K, V]) Contains(key K) bool {
+	_, ok := l.items[key]
+	return ok
+}
+
+// Peek returns the value for key without marking it as most recently used.
+func (l *BasicLRU[K, V]) Peek(key K) (value V, ok bool) {
+	node, ok := l.items[key]
+	if !ok {
+		return value, false
+	}
+	return node.value, true
+}
+
+// Delete a value for a key
+func (l *BasicLRU[K, V]) Delete(key K) (prev V, deleted bool) {
+	node, ok := l.items[key]
+	if !ok {
+		return prev, false
+	}
+	l.unlink(node)
+	delete(l.items, key)
+	return node.value, true
+}
+
+// Evict evicts the least recently used item from the cache.
+func (l *BasicLRU[K, V]) Evict() (key K, value V, evicted bool) {
+	node := l.root.next
+	if node == &l.root {
+		return key, value, false
+	}
+	l.unlink(node)
+	delete(l.items, node.key)
+	key, value = node.key, node.value
+	if l.evicted != nil {
+		l.evicted(key, value)
+	}
+	return key, value, true
+}
+
+// Resize resizes the cache to the specified size.
+func (l *BasicLRU[K, V]) Resize(size int) {
+	l.size = uint64(size)
+	for uint64(len(l.items)) > l.size {
+		if _, _, evicted := l.Evict(); !evicted {
+			break
+		}
+	}
+}
+
+// ForEach iterates over the cache, calling f for each item, most recently
+// used first.
+func (l *BasicLRU[K, V]) ForEach(iter func(key K, value V) bool) {
+	for node := l.root.prev; node != &l.root; node = node.prev {
+		if !iter(node.key, node.value) {
+			return
+		}
+	}
+}
+
+// Keys returns a slice of the keys in the cache.
+func (l *BasicLRU[K, V]) Keys() []K {
+	keys := make([]K, 0, l.Len())
+	l.ForEach(func(key K, value V) bool {
+		keys = append(keys, key)
+		return true
+	})
+	return keys
+}
+
+// Values returns a slice of the values in the cache.
+func (l *BasicLRU[K, V]) Values() []V {
+	values := make([]V, 0, l.Len())
+	l.ForEach(func(key K, value V) bool {
+		values = append(values, value)
+		return true
+	})
+	return values
+}
+
+// Close is a no-op kept for parity with the other cache types; BasicLRU
+// has no background goroutines to stop.
+func (l *BasicLRU[K, V]) Close() {
+}
@@ -0,0 +1,100 @@
+package lru
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func testBasicStep(t *testing.T, lru *BasicLRU[string, string], c, l int, keys []string, values []string) {
+	if l != lru.Len() {
+		t.Errorf("got len = %d, want %d", lru.Len(), l)
+	}
+	if c != lru.Cap() {
+		t.Errorf("got cap = %d, want %d", lru.Cap(), c)
+	}
+	if k := lru.Keys(); !reflect.DeepEqual(keys, k) {
+		t.Errorf("got keys = %v, want %v", k, keys)
+	}
+	if v := lru.Values(); !reflect.DeepEqual(values, v) {
+		t.Errorf("got values = %v, want %v", v, values)
+	}
+}
+
+func TestBasicLRU(t *testing.T) {
+	var evicted []string
+	lru := NewBasicLRU[string, string](4, func(k, v string) {
+		evicted = append(evicted, k)
+	})
+	testBasicStep(t, lru, 4, 0, []string{}, []string{})
+
+	_, _, ok := lru.Evict()
+	if ok {
+		t.Errorf("evicted = %v, want %v", ok, false)
+	}
+
+	for i := 0; i < 5; i++ {
+		lru.Put(fmt.Sprintf("key%d", i), fmt.Sprintf("value%d", i))
+	}
+	testBasicStep(t, lru, 4, 4,
+		[]string{"key4", "key3", "key2", "key1"},
+		[]string{"value4", "value3", "value2", "value1"},
+	)
+	if len(evicted) != 1 || evicted[0] != "key0" {
+		t.Errorf("got evicted = %v, want [key0]", evicted)
+	}
+
+	if v, ok := lru.Peek("key2"); !ok || v != "value2" {
+		t.Errorf("got peek(key2) = %q, %v, want value2, true", v, ok)
+	}
+	testBasicStep(t, lru, 4, 4,
+		[]string{"key4", "key3", "key2", "key1"},
+		[]string{"value4", "value3", "value2", "value1"},
+	)
+
+	if v, ok := lru.Get("key2"); !ok || v != "value2" {
+		t.Errorf("got get(key2) = %q, %v, want value2, true", v, ok)
+	}
+	testBasicStep(t, lru, 4, 4,
+		[]string{"key2", "key4", "key3", "key1"},
+		[]string{"value2", "value4", "value3", "value1"},
+	)
+
+	if !lru.Contains("key1") {
+		t.Errorf("key1 should be in lru")
+	}
+	if lru.Contains("key0") {
+		t.Errorf("key0 should not be in lru")
+	}
+
+	if v, ok := lru.Delete("key1"); !ok || v != "value1" {
+		t.Errorf("got delete(key1) = %q, %v, want value1, true", v, ok)
+	}
+	testBasicStep(t, lru, 4, 3,
+		[]string{"key2", "key4", "key3"},
+		[]string{"value2", "value4", "value3"},
+	)
+
+	lru.Resize(2)
+	testBasicStep(t, lru, 2, 2,
+		[]string{"key2", "key4"},
+		[]string{"value2", "value4"},
+	)
+}
+
+// TestBasicLRUPutReusesEvictedNode checks that Put at capacity doesn't
+// allocate a new node for the incoming entry, reusing the evicted one
+// instead.
+func TestBasicLRUPutReusesEvictedNode(t *testing.T) {
+	lru := NewBasicLRU[int, int](2, nil)
+	lru.Put(1, 1)
+	lru.Put(2, 2)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		lru.Put(3, 3)
+		lru.Put(4, 4)
+	})
+	if allocs != 0 {
+		t.Errorf("got %v allocs per Put at capacity, want 0", allocs)
+	}
+}
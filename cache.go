@@ -0,0 +1,50 @@
+package lru
+
+// Cache is the common interface implemented by every eviction policy in
+// this package (LRU, ARC, ...). It lets callers depend on the policy
+// abstractly and swap implementations without touching call sites.
+type Cache[K comparable, V any] interface {
+	// Put sets the value for the specified key.
+	Put(key K, value V) (prev V, replaced bool)
+
+	// Get returns a value for key and mark it as most recently used.
+	Get(key K) (value V, ok bool)
+
+	// Peek returns the value for key without marking it as most recently used.
+	Peek(key K) (value V, ok bool)
+
+	// Contains returns true if the key exists.
+	Contains(key K) bool
+
+	// Delete a value for a key
+	Delete(key K) (prev V, deleted bool)
+
+	// Evict evicts the least recently used item from the cache.
+	Evict() (key K, value V, evicted bool)
+
+	// Resize resizes the cache to the specified size.
+	Resize(size int)
+
+	// Len returns the length of the cache.
+	Len() int
+
+	// Cap returns the capacity of the cache.
+	Cap() int
+
+	// ForEach iterates over the cache, calling f for each item.
+	ForEach(iter func(key K, value V) bool)
+
+	// Keys returns a slice of the keys in the cache.
+	Keys() []K
+
+	// Values returns a slice of the values in the cache.
+	Values() []V
+
+	// Close closes the cache.
+	Close()
+}
+
+var (
+	_ Cache[int, int] = (*LRU[int, int])(nil)
+	_ Cache[int, int] = (*ARC[int, int])(nil)
+)
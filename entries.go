@@ -4,7 +4,9 @@ import (
 	"sync"
 )
 
-// entries is thread-safe entry for LRU
+// entries is a thread-safe entry used by the cache variants that still
+// keep their items behind internal/container/list (ARC, Sieve, SizedLRU).
+// LRU itself stores its items directly in BasicLRU instead; see basiclru.go.
 type entries[K comparable, V any] struct {
 	mut   sync.RWMutex
 	key   K
@@ -0,0 +1,130 @@
+// Package timingwheel implements a hashed timing wheel, the same approach
+// go-zero's TimingWheel uses to schedule a large number of deadlines
+// without a goroutine or timer per entry.
+package timingwheel
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// Wheel schedules opaque keys to fire roughly after a delay. It only
+// tracks keys; the caller decides what firing means via the execute
+// function passed to New. A delay longer than one revolution (slotCount
+// ticks) is handled by storing the number of additional revolutions the
+// key must wait out in its slot, the same as go-zero's TimingWheel.
+type Wheel[K comparable] struct {
+	mut   sync.Mutex
+	tick  time.Duration
+	slots []map[K]int // key -> remaining revolutions before it's due
+	pos   map[K]int
+	hand  int
+
+	execute func(K)
+	ticker  *time.Ticker
+	stopCh  chan struct{}
+}
+
+// New returns a Wheel with slotCount slots, each spanning tick, that calls
+// execute for every key whose schedule fires. Call Start to begin advancing
+// the wheel.
+func New[K comparable](tick time.Duration, slotCount int, execute func(K)) *Wheel[K] {
+	slots := make([]map[K]int, slotCount)
+	for i := range slots {
+		slots[i] = make(map[K]int)
+	}
+	return &Wheel[K]{
+		tick:    tick,
+		slots:   slots,
+		pos:     make(map[K]int),
+		execute: execute,
+		stopCh:  make(chan struct{}),
+	}
+}
+
+// Start begins advancing the wheel in a background goroutine.
+func (w *Wheel[K]) Start() {
+	w.ticker = time.NewTicker(w.tick)
+	go w.run()
+}
+
+// Stop halts the background goroutine. It must be called at most once.
+func (w *Wheel[K]) Stop() {
+	close(w.stopCh)
+}
+
+func (w *Wheel[K]) run() {
+	for {
+		select {
+		case <-w.ticker.C:
+			w.advance()
+		case <-w.stopCh:
+			w.ticker.Stop()
+			return
+		}
+	}
+}
+
+func (w *Wheel[K]) advance() {
+	w.mut.Lock()
+	slot := w.slots[w.hand]
+	due := make([]K, 0, len(slot))
+	for key, rounds := range slot {
+		if rounds > 0 {
+			slot[key] = rounds - 1
+			continue
+		}
+		due = append(due, key)
+		delete(slot, key)
+		delete(w.pos, key)
+	}
+	w.hand = (w.hand + 1) % len(w.slots)
+	w.mut.Unlock()
+
+	for _, key := range due {
+		w.execute(key)
+	}
+}
+
+// Schedule arranges for key to fire after roughly delay. The actual delay
+// is jittered up by up to 10% so that many keys scheduled together don't
+// all expire in the same tick. The jitter is one-sided (never negative) so
+// the wheel never fires before the real deadline. Scheduling a key that is
+// already scheduled replaces its previous schedule.
+func (w *Wheel[K]) Schedule(key K, delay time.Duration) {
+	if delay <= 0 {
+		delay = w.tick
+	}
+	jitter := time.Duration(rand.Float64() * 0.1 * float64(delay))
+	total := delay + jitter
+	// Round up: a slot computed by truncating division fires at most one
+	// tick before total has elapsed, which is before the real deadline.
+	ticks := int((total + w.tick - 1) / w.tick)
+	if ticks < 1 {
+		ticks = 1
+	}
+
+	n := len(w.slots)
+	rounds := ticks / n
+	offset := ticks % n
+
+	w.mut.Lock()
+	defer w.mut.Unlock()
+	if slot, ok := w.pos[key]; ok {
+		delete(w.slots[slot], key)
+	}
+	slot := (w.hand + offset) % n
+	w.slots[slot][key] = rounds
+	w.pos[key] = slot
+}
+
+// Remove cancels a scheduled fire for key, if any.
+func (w *Wheel[K]) Remove(key K) {
+	w.mut.Lock()
+	defer w.mut.Unlock()
+	if slot, ok := w.pos[key]; ok {
+		delete(w.slots[slot], key)
+		delete(w.pos, key)
+	}
+}
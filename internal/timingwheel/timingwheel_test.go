@@ -0,0 +1,35 @@
+package timingwheel
+
+import (
+	"testing"
+	"time"
+)
+
+// TestWheelMultiRevolution schedules a delay several revolutions long on a
+// tiny wheel and drives it with direct advance() calls (no real sleeping)
+// to check the key survives intermediate passes over its slot and fires
+// only once the full delay has actually elapsed.
+func TestWheelMultiRevolution(t *testing.T) {
+	var fired []string
+	w := New[string](time.Millisecond, 3, func(key string) {
+		fired = append(fired, key)
+	})
+
+	// delay spans more than one revolution (3 slots), so Schedule must
+	// track the extra revolutions instead of colliding onto the next lap.
+	w.Schedule("a", 10*time.Millisecond)
+
+	for i := 0; i < 10; i++ {
+		w.advance()
+	}
+	if len(fired) != 0 {
+		t.Fatalf("got fired = %v after 10 advances, want none", fired)
+	}
+
+	for i := 0; i < 2; i++ {
+		w.advance()
+	}
+	if len(fired) != 1 || fired[0] != "a" {
+		t.Fatalf("got fired = %v after 12 advances, want [a]", fired)
+	}
+}
@@ -0,0 +1,60 @@
+package lru
+
+import (
+	"context"
+	"sync"
+)
+
+// loaderCall represents an in-flight or completed loader invocation for a
+// single key, shared by every concurrent caller that misses on that key.
+type loaderCall[V any] struct {
+	wg  sync.WaitGroup
+	val V
+	err error
+}
+
+// GetOrLoad returns the cached value for key, calling loader to populate
+// it on a miss. Concurrent callers that miss on the same key share a
+// single in-flight call to loader instead of each invoking it themselves;
+// all of them receive that one call's result once it returns, and a
+// successful load is stored in the cache exactly like Put would.
+func (l *LRU[K, V]) GetOrLoad(key K, loader func(K) (V, error)) (V, error) {
+	return l.GetOrLoadContext(context.Background(), key, func(_ context.Context, key K) (V, error) {
+		return loader(key)
+	})
+}
+
+// GetOrLoadContext is GetOrLoad with a context threaded through to loader,
+// so callers can cancel or bound an in-flight load.
+func (l *LRU[K, V]) GetOrLoadContext(ctx context.Context, key K, loader func(context.Context, K) (V, error)) (V, error) {
+	if value, ok := l.Get(key); ok {
+		return value, nil
+	}
+
+	l.loaderMut.Lock()
+	if l.loaders == nil {
+		l.loaders = make(map[K]*loaderCall[V])
+	}
+	if c, ok := l.loaders[key]; ok {
+		l.loaderMut.Unlock()
+		c.wg.Wait()
+		return c.val, c.err
+	}
+
+	c := new(loaderCall[V])
+	c.wg.Add(1)
+	l.loaders[key] = c
+	l.loaderMut.Unlock()
+
+	c.val, c.err = loader(ctx, key)
+	if c.err == nil {
+		l.Put(key, c.val)
+	}
+
+	l.loaderMut.Lock()
+	delete(l.loaders, key)
+	l.loaderMut.Unlock()
+	c.wg.Done()
+
+	return c.val, c.err
+}
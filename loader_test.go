@@ -0,0 +1,55 @@
+package lru
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestGetOrLoad(t *testing.T) {
+	lru := NewLRU[string, int](4, nil)
+	defer lru.Close()
+
+	var calls int32
+	var wg sync.WaitGroup
+	results := make([]int, 8)
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			v, err := lru.GetOrLoad("key", func(string) (int, error) {
+				atomic.AddInt32(&calls, 1)
+				return 42, nil
+			})
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			results[i] = v
+		}(i)
+	}
+	wg.Wait()
+
+	if calls != 1 {
+		t.Errorf("got %d loader calls, want 1", calls)
+	}
+	for i, v := range results {
+		if v != 42 {
+			t.Errorf("result[%d] = %d, want 42", i, v)
+		}
+	}
+	if v, ok := lru.Peek("key"); !ok || v != 42 {
+		t.Errorf("got peek(key) = %d, %v, want 42, true", v, ok)
+	}
+
+	errLoad := errors.New("load failed")
+	_, err := lru.GetOrLoad("missing", func(string) (int, error) {
+		return 0, errLoad
+	})
+	if !errors.Is(err, errLoad) {
+		t.Errorf("got err = %v, want %v", err, errLoad)
+	}
+	if lru.Contains("missing") {
+		t.Errorf("a failed load should not populate the cache")
+	}
+}
@@ -3,190 +3,190 @@ package lru
 import (
 	"sync"
 	"sync/atomic"
+	"time"
 
-	"github.com/wzshiming/lru/internal/container/list"
-	syncmap "github.com/wzshiming/lru/internal/sync"
+	"github.com/wzshiming/lru/internal/timingwheel"
 )
 
-// LRU is a thread-safe fixed size LRU cache.
+// ttlValue is what LRU actually stores in its BasicLRU core: the value the
+// caller asked to cache, plus an optional absolute deadline.
+type ttlValue[V any] struct {
+	value     V
+	expiresAt int64 // unix nano deadline; zero means no expiration
+}
+
+// LRU is a thread-safe fixed size LRU cache: a thin, single-mutex wrapper
+// around BasicLRU that adds concurrency safety, TTL expiration, runtime
+// stats and a reason-aware eviction callback.
 type LRU[K comparable, V any] struct {
 	mut sync.Mutex
 
-	size uint64 // maximum number of items in cache
+	core *BasicLRU[K, ttlValue[V]]
 
-	items syncmap.Map[K, *list.Element[entries[K, V]]] // map of items in cache
+	userEvicted   func(K, V, EvictReason)
+	pendingReason EvictReason // reason core's evicted callback should report for the call about to be made; only meaningful while mut is held
 
-	linked  *linked[entries[K, V]] // linked list of items in cache
-	evicted func(K, V)             // callback function when an item is evicted
+	stats lruStats // running counters, see Stats
 
-	evictCh    chan struct{}                     // evict channel
-	recentlyCh chan *list.Element[entries[K, V]] // recently used channel
+	defaultTTL time.Duration         // TTL applied by Put when non-zero; set by NewLRUWithTTL
+	wheel      *timingwheel.Wheel[K] // active sweeper for TTL expiration, nil unless TTL is in use
+
+	loaderMut sync.Mutex           // guards loaders, separate from mut so a slow loader can't block Put/Get
+	loaders   map[K]*loaderCall[V] // in-flight GetOrLoad calls, keyed by key
 
 	isClosed uint32
 }
 
-// NewLRU returns a new LRU of the given size.
+// NewLRU returns a new LRU of the given size. evicted, if non-nil, is
+// called whenever an item is evicted (capacity, expiry, Evict or Resize;
+// Delete does not call it); use NewLRUWithReason if you need to know why.
 func NewLRU[K comparable, V any](size int, evicted func(K, V)) *LRU[K, V] {
-	l := &LRU[K, V]{
-		linked:     newLinked[entries[K, V]](),
-		size:       uint64(size),
-		evicted:    evicted,
-		evictCh:    make(chan struct{}, 1),
-		recentlyCh: make(chan *list.Element[entries[K, V]], 128),
+	var wrapped func(K, V, EvictReason)
+	if evicted != nil {
+		wrapped = func(k K, v V, _ EvictReason) { evicted(k, v) }
 	}
-	go l.channelRecently()
-	go l.channelEvict()
-	return l
+	return newLRU[K, V](size, wrapped)
 }
 
-func (l *LRU[K, V]) channelRecently() {
-	for atomic.LoadUint32(&l.isClosed) == 0 {
-		select {
-		case item := <-l.recentlyCh:
-			l.linked.MoveToBack(item)
-		}
-	}
+// NewLRUWithReason returns a new LRU of the given size whose eviction
+// callback is also told why the item was removed (ran out of room, its
+// TTL expired, a manual Evict, or a Resize).
+func NewLRUWithReason[K comparable, V any](size int, evicted func(K, V, EvictReason)) *LRU[K, V] {
+	return newLRU[K, V](size, evicted)
 }
 
-func (l *LRU[K, V]) channelEvict() {
-	for atomic.LoadUint32(&l.isClosed) == 0 {
-		select {
-		case <-l.evictCh:
-			for l.Len() > l.Cap() {
-				l.evict()
-			}
+func newLRU[K comparable, V any](size int, evicted func(K, V, EvictReason)) *LRU[K, V] {
+	l := &LRU[K, V]{userEvicted: evicted}
+	l.core = NewBasicLRU[K, ttlValue[V]](size, func(key K, v ttlValue[V]) {
+		l.stats.recordEvict(l.pendingReason)
+		if l.userEvicted != nil {
+			l.userEvicted(key, v.value, l.pendingReason)
 		}
-	}
-}
-
-func (l *LRU[K, V]) toRecently(item *list.Element[entries[K, V]]) {
-	l.recentlyCh <- item
-}
-
-func (l *LRU[K, V]) tryEvict() {
-	select {
-	case l.evictCh <- struct{}{}:
-	default:
-	}
+	})
+	return l
 }
 
 // Evict evicts the least recently used item from the cache.
 func (l *LRU[K, V]) Evict() (key K, value V, evicted bool) {
-	item := l.evict()
-	if item == nil {
-		return
-	}
-
-	key, value = item.Value.get()
-	return key, value, true
-}
+	l.mut.Lock()
+	defer l.mut.Unlock()
 
-func (l *LRU[K, V]) evict() *list.Element[entries[K, V]] {
-	node := l.linked.Front()
-	if node == nil {
-		return nil
+	l.pendingReason = EvictManual
+	key, wrapped, evicted := l.core.Evict()
+	if !evicted {
+		return key, value, false
 	}
-
-	item := l.linked.Remove(node)
-	key, value := item.get()
-	l.items.Delete(key)
-	if l.evicted != nil {
-		l.evicted(key, value)
+	if l.wheel != nil {
+		l.wheel.Remove(key)
 	}
-	return node
+	return key, wrapped.value, true
 }
 
 // Resize resizes the cache to the specified size.
 func (l *LRU[K, V]) Resize(size int) {
-	atomic.StoreUint64(&l.size, uint64(size))
-	l.tryEvict()
-	return
+	l.mut.Lock()
+	defer l.mut.Unlock()
+
+	l.pendingReason = EvictResize
+	l.core.Resize(size)
 }
 
 // Len returns the length of the lru cache
 func (l *LRU[K, V]) Len() int {
-	return l.linked.Len()
+	l.mut.Lock()
+	defer l.mut.Unlock()
+	return l.core.Len()
 }
 
 // Cap returns the capacity of the lru cache
 func (l *LRU[K, V]) Cap() int {
-	return int(atomic.LoadUint64(&l.size))
+	l.mut.Lock()
+	defer l.mut.Unlock()
+	return l.core.Cap()
 }
 
 // Put sets the value for the specified key.
 func (l *LRU[K, V]) Put(key K, value V) (prev V, replaced bool) {
-	item, ok := l.items.Load(key)
-	// key exists in cache already so we update it
-	if ok && item != nil {
-		l.toRecently(item)
-		prev = item.Value.set(value)
-		return prev, true
-	}
-
-	l.mut.Lock()
-	defer l.mut.Unlock()
-	item, ok = l.items.Load(key)
-	// re-check if key exists in cache after we acquire the lock
-	if ok && item != nil {
-		l.toRecently(item)
-		prev = item.Value.set(value)
-		return prev, true
-	}
-
-	// key doesn't exist in cache so we add it
-	item = l.linked.PushBack(entries[K, V]{key: key, value: value})
-	l.items.Store(key, item)
-
-	l.tryEvict()
-	return
+	return l.PutWithTTL(key, value, l.defaultTTL)
 }
 
 // Get returns a value for key and mark it as most recently used.
 func (l *LRU[K, V]) Get(key K) (value V, ok bool) {
-	item, ok := l.items.Load(key)
-	if !ok || item == nil {
-		return
+	l.mut.Lock()
+	defer l.mut.Unlock()
+
+	node, ok := l.core.items[key]
+	if !ok {
+		atomic.AddUint64(&l.stats.misses, 1)
+		return value, false
+	}
+	if l.isExpired(node) {
+		l.removeExpiredNode(key, node)
+		atomic.AddUint64(&l.stats.misses, 1)
+		return value, false
 	}
 
-	l.toRecently(item)
-	_, value = item.Value.get()
-	return value, true
+	l.core.moveToBack(node)
+	atomic.AddUint64(&l.stats.hits, 1)
+	return node.value.value, true
 }
 
 // Contains returns true if the key exists.
 func (l *LRU[K, V]) Contains(key K) bool {
-	_, ok := l.items.Load(key)
-	return ok
+	l.mut.Lock()
+	defer l.mut.Unlock()
+
+	node, ok := l.core.items[key]
+	if !ok {
+		return false
+	}
+	if l.isExpired(node) {
+		l.removeExpiredNode(key, node)
+		return false
+	}
+	return true
 }
 
 // Peek returns the value for key without marking it as most recently used.
 func (l *LRU[K, V]) Peek(key K) (value V, ok bool) {
-	item, ok := l.items.Load(key)
-	if !ok || item == nil {
-		return
-	}
+	l.mut.Lock()
+	defer l.mut.Unlock()
 
-	_, value = item.Value.get()
-	return value, true
+	node, ok := l.core.items[key]
+	if !ok {
+		return value, false
+	}
+	if l.isExpired(node) {
+		l.removeExpiredNode(key, node)
+		return value, false
+	}
+	return node.value.value, true
 }
 
 // Delete a value for a key
 func (l *LRU[K, V]) Delete(key K) (prev V, deleted bool) {
-	item, ok := l.items.LoadAndDelete(key)
-	if !ok || item == nil {
-		return
-	}
+	l.mut.Lock()
+	defer l.mut.Unlock()
 
-	l.linked.Remove(item)
-	_, prev = item.Value.get()
-	return prev, true
+	node, ok := l.core.items[key]
+	if !ok {
+		return prev, false
+	}
+	l.core.unlink(node)
+	delete(l.core.items, key)
+	if l.wheel != nil {
+		l.wheel.Remove(key)
+	}
+	return node.value.value, true
 }
 
 // ForEach iterates over the cache, calling f for each item.
 func (l *LRU[K, V]) ForEach(iter func(key K, value V) bool) {
-	l.linked.ForEach(func(item *list.Element[entries[K, V]]) bool {
-		key, value := item.Value.get()
-		return iter(key, value)
+	l.mut.Lock()
+	defer l.mut.Unlock()
+
+	l.core.ForEach(func(key K, v ttlValue[V]) bool {
+		return iter(key, v.value)
 	})
 }
 
@@ -212,5 +212,10 @@ func (l *LRU[K, V]) Values() []V {
 
 // Close closes the cache.
 func (l *LRU[K, V]) Close() {
-	atomic.StoreUint32(&l.isClosed, 1)
+	if !atomic.CompareAndSwapUint32(&l.isClosed, 0, 1) {
+		return
+	}
+	if l.wheel != nil {
+		l.wheel.Stop()
+	}
 }
@@ -0,0 +1,216 @@
+package lru
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/wzshiming/lru/internal/container/list"
+	syncmap "github.com/wzshiming/lru/internal/sync"
+)
+
+// sieveEntries is a SIEVE node: the usual key/value pair plus the single
+// "visited" bit the algorithm relies on. visited is touched by Get/Contains
+// without holding the cache mutex, so it is a plain uint32 flipped with
+// atomic operations.
+type sieveEntries[K comparable, V any] struct {
+	entries[K, V]
+	visited uint32
+}
+
+// Sieve is a thread-safe fixed size cache implementing the SIEVE eviction
+// algorithm. Like LRU it is a doubly-linked list plus a hashmap, but a hit
+// only flips a visited bit instead of moving the node, so Get/Contains
+// never touch the list and are much cheaper under concurrent load. New
+// entries are pushed to the back of the list (the "head") and a single
+// hand walks the list from the front (the "tail") to find something to
+// evict.
+type Sieve[K comparable, V any] struct {
+	mut sync.Mutex
+
+	size uint64 // maximum number of items in cache
+
+	items syncmap.Map[K, *list.Element[sieveEntries[K, V]]] // map of items in cache
+
+	linked  *linked[sieveEntries[K, V]]       // linked list of items in cache
+	hand    *list.Element[sieveEntries[K, V]] // eviction hand, nil means "restart from the tail"
+	evicted func(K, V)                        // callback function when an item is evicted
+}
+
+// NewSieve returns a new Sieve of the given size.
+func NewSieve[K comparable, V any](size int, evicted func(K, V)) *Sieve[K, V] {
+	return &Sieve[K, V]{
+		linked:  newLinked[sieveEntries[K, V]](),
+		size:    uint64(size),
+		evicted: evicted,
+	}
+}
+
+// Len returns the length of the sieve cache
+func (s *Sieve[K, V]) Len() int {
+	return s.linked.Len()
+}
+
+// Cap returns the capacity of the sieve cache
+func (s *Sieve[K, V]) Cap() int {
+	return int(atomic.LoadUint64(&s.size))
+}
+
+// Put sets the value for the specified key.
+func (s *Sieve[K, V]) Put(key K, value V) (prev V, replaced bool) {
+	if item, ok := s.items.Load(key); ok && item != nil {
+		atomic.StoreUint32(&item.Value.visited, 1)
+		prev = item.Value.set(value)
+		return prev, true
+	}
+
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	item, ok := s.items.Load(key)
+	if ok && item != nil {
+		atomic.StoreUint32(&item.Value.visited, 1)
+		prev = item.Value.set(value)
+		return prev, true
+	}
+
+	for s.linked.Len() >= int(atomic.LoadUint64(&s.size)) && s.linked.Len() > 0 {
+		s.evict()
+	}
+
+	item = s.linked.PushBack(sieveEntries[K, V]{entries: entries[K, V]{key: key, value: value}})
+	s.items.Store(key, item)
+	return prev, false
+}
+
+// evict runs the SIEVE hand and removes the first unvisited node it finds,
+// clearing the visited bit of every node it passes over. The scan wraps
+// around to the front of the list instead of stopping there, so a node
+// that is still visited when the hand reaches the end gets its bit
+// cleared and another pass, the same as any other node. Callers must hold
+// s.mut.
+func (s *Sieve[K, V]) evict() (key K, value V, evicted bool) {
+	node := s.hand
+	if node == nil {
+		node = s.linked.Front()
+	}
+	if node == nil {
+		return key, value, false
+	}
+
+	for atomic.LoadUint32(&node.Value.visited) == 1 {
+		atomic.StoreUint32(&node.Value.visited, 0)
+		next := node.Next()
+		if next == nil {
+			next = s.linked.Front()
+		}
+		node = next
+	}
+
+	s.hand = node.Prev()
+	item := s.linked.Remove(node)
+	key, value = item.get()
+	s.items.Delete(key)
+	if s.evicted != nil {
+		s.evicted(key, value)
+	}
+	return key, value, true
+}
+
+// Evict evicts an item from the cache, chosen by the SIEVE hand.
+func (s *Sieve[K, V]) Evict() (key K, value V, evicted bool) {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	return s.evict()
+}
+
+// Resize resizes the cache to the specified size.
+func (s *Sieve[K, V]) Resize(size int) {
+	atomic.StoreUint64(&s.size, uint64(size))
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	for s.linked.Len() > int(atomic.LoadUint64(&s.size)) {
+		s.evict()
+	}
+}
+
+// Get returns a value for key and marks it as visited.
+func (s *Sieve[K, V]) Get(key K) (value V, ok bool) {
+	item, ok := s.items.Load(key)
+	if !ok || item == nil {
+		return value, false
+	}
+	atomic.StoreUint32(&item.Value.visited, 1)
+	_, value = item.Value.get()
+	return value, true
+}
+
+// Contains returns true if the key exists, marking it as visited.
+func (s *Sieve[K, V]) Contains(key K) bool {
+	item, ok := s.items.Load(key)
+	if !ok || item == nil {
+		return false
+	}
+	atomic.StoreUint32(&item.Value.visited, 1)
+	return true
+}
+
+// Peek returns the value for key without marking it as visited.
+func (s *Sieve[K, V]) Peek(key K) (value V, ok bool) {
+	item, ok := s.items.Load(key)
+	if !ok || item == nil {
+		return value, false
+	}
+	_, value = item.Value.get()
+	return value, true
+}
+
+// Delete a value for a key
+func (s *Sieve[K, V]) Delete(key K) (prev V, deleted bool) {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	item, ok := s.items.LoadAndDelete(key)
+	if !ok || item == nil {
+		return prev, false
+	}
+	if s.hand == item {
+		s.hand = item.Prev()
+	}
+	s.linked.Remove(item)
+	_, prev = item.Value.get()
+	return prev, true
+}
+
+// ForEach iterates over the cache, calling f for each item.
+func (s *Sieve[K, V]) ForEach(iter func(key K, value V) bool) {
+	s.linked.ForEach(func(item *list.Element[sieveEntries[K, V]]) bool {
+		key, value := item.Value.get()
+		return iter(key, value)
+	})
+}
+
+// Keys returns a slice of the keys in the cache.
+func (s *Sieve[K, V]) Keys() []K {
+	keys := make([]K, 0, s.Len())
+	s.ForEach(func(key K, value V) bool {
+		keys = append(keys, key)
+		return true
+	})
+	return keys
+}
+
+// Values returns a slice of the values in the cache.
+func (s *Sieve[K, V]) Values() []V {
+	values := make([]V, 0, s.Len())
+	s.ForEach(func(key K, value V) bool {
+		values = append(values, value)
+		return true
+	})
+	return values
+}
+
+// Close closes the cache. Sieve runs no background goroutines, so this is
+// a no-op kept for Cache interface compatibility.
+func (s *Sieve[K, V]) Close() {
+}
+
+var _ Cache[int, int] = (*Sieve[int, int])(nil)
@@ -0,0 +1,141 @@
+package lru
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func testSieveStep(t *testing.T, sieve *Sieve[string, string], c, l int, keys []string, values []string) {
+	if l != sieve.Len() {
+		t.Errorf("got len = %d, want %d", sieve.Len(), l)
+	}
+	if c != sieve.Cap() {
+		t.Errorf("got cap = %d, want %d", sieve.Cap(), c)
+	}
+	if k := sieve.Keys(); !reflect.DeepEqual(keys, k) {
+		t.Errorf("got keys = %v, want %v", k, keys)
+	}
+	if v := sieve.Values(); !reflect.DeepEqual(values, v) {
+		t.Errorf("got values = %v, want %v", v, values)
+	}
+	// Peek is used here, not Contains or Get, because both of those mark
+	// the key visited as a side effect and would corrupt the SIEVE hand
+	// state this test exercises later.
+	for i, k := range keys {
+		if v, ok := sieve.Peek(k); !ok {
+			t.Errorf("key %q not found", k)
+		} else if v != values[i] {
+			t.Errorf("key %q = %q, want %q", k, v, values[i])
+		}
+	}
+}
+
+func TestSieve(t *testing.T) {
+	sieve := NewSieve[string, string](4, func(k string, v string) {
+		t.Logf("evict key: %s, value: %s", k, v)
+	})
+	defer sieve.Close()
+	testSieveStep(t, sieve, 4, 0,
+		[]string{},
+		[]string{},
+	)
+
+	_, _, evicted := sieve.Evict()
+	if evicted {
+		t.Errorf("evicted = %v, want %v", evicted, false)
+	}
+
+	for i := 0; i < 4; i++ {
+		sieve.Put(fmt.Sprintf("key%d", i), fmt.Sprintf("value%d", i))
+	}
+	testSieveStep(t, sieve, 4, 4,
+		[]string{"key3", "key2", "key1", "key0"},
+		[]string{"value3", "value2", "value1", "value0"},
+	)
+
+	// visiting key0..key2 marks them, so the next eviction should skip them
+	// and take key3, which was never visited.
+	sieve.Get("key0")
+	sieve.Get("key1")
+	sieve.Get("key2")
+
+	sieve.Put("key4", "value4")
+	if sieve.Contains("key3") {
+		t.Errorf("key3 should have been evicted")
+	}
+	if !sieve.Contains("key0") || !sieve.Contains("key4") {
+		t.Errorf("key0 and key4 should be in sieve")
+	}
+	if sieve.Len() != 4 {
+		t.Errorf("got len = %d, want 4", sieve.Len())
+	}
+
+	_, okpkey5 := sieve.Peek("key5")
+	if okpkey5 {
+		t.Errorf("key5 should not be in sieve")
+	}
+	_, okpkey5 = sieve.Get("key5")
+	if okpkey5 {
+		t.Errorf("key5 should not be in sieve")
+	}
+	_, okpkey5 = sieve.Delete("key5")
+	if okpkey5 {
+		t.Errorf("key5 should not be in sieve")
+	}
+
+	sieve.Delete("key0")
+	if sieve.Contains("key0") {
+		t.Errorf("key0 should have been deleted")
+	}
+	if sieve.Len() != 3 {
+		t.Errorf("got len = %d, want 3", sieve.Len())
+	}
+
+	sieve.Resize(2)
+	if sieve.Len() > 2 || sieve.Cap() != 2 {
+		t.Errorf("got len, cap = %d, %d, want <=2, 2", sieve.Len(), sieve.Cap())
+	}
+}
+
+// TestSieveHandWraparound exercises an eviction where the hand runs off
+// the back of the list before finding an unvisited node, so it must wrap
+// around to the front and resume the same visited-check there instead of
+// evicting whatever it lands on.
+func TestSieveHandWraparound(t *testing.T) {
+	sieve := NewSieve[string, string](6, func(k string, v string) {
+		t.Logf("evict key: %s, value: %s", k, v)
+	})
+	defer sieve.Close()
+
+	for _, k := range []string{"a", "b", "c", "d", "e", "f"} {
+		sieve.Put(k, "value"+k)
+	}
+
+	// Mark a, b, c visited so the hand's first sweep clears them and
+	// stops at d, leaving the hand positioned at c (the node before d).
+	sieve.Get("a")
+	sieve.Get("b")
+	sieve.Get("c")
+	key, _, evicted := sieve.Evict()
+	if !evicted || key != "d" {
+		t.Fatalf("got evicted key = %q, %v, want %q, true", key, evicted, "d")
+	}
+
+	// Mark every node from the hand (c) onward, including wrapping past
+	// the back of the list to the front (a), visited, with only b left
+	// unvisited. A correctly wrapping hand clears a's bit on the way past
+	// it and evicts b; a hand that evicts whatever it lands on at the
+	// front instead would evict a despite never clearing its bit.
+	sieve.Get("a")
+	sieve.Get("c")
+	sieve.Get("e")
+	sieve.Get("f")
+	key, _, evicted = sieve.Evict()
+	if !evicted || key != "b" {
+		t.Fatalf("got evicted key = %q, %v, want %q, true", key, evicted, "b")
+	}
+	if !sieve.Contains("a") {
+		t.Errorf("key \"a\" should have survived the wraparound, not been evicted in its place")
+	}
+}
@@ -0,0 +1,263 @@
+package lru
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/wzshiming/lru/internal/container/list"
+	syncmap "github.com/wzshiming/lru/internal/sync"
+)
+
+// sizedEntries is a SizedLRU node: the usual key/value pair plus the
+// charge that entry was inserted with, so eviction can unwind the running
+// total without recomputing sizeOf.
+type sizedEntries[K comparable, V any] struct {
+	entries[K, V]
+	charge int64
+}
+
+// setCharge updates value and charge together, under the embedded entry's
+// own lock, and returns what they were before.
+func (e *sizedEntries[K, V]) setCharge(value V, charge int64) (prevValue V, prevCharge int64) {
+	e.mut.Lock()
+	defer e.mut.Unlock()
+	prevValue, prevCharge = e.value, e.charge
+	e.value, e.charge = value, charge
+	return prevValue, prevCharge
+}
+
+// SizedLRU is a thread-safe LRU cache whose capacity is measured in a
+// caller-supplied "charge" per entry (bytes, tokens, whatever) rather than
+// a fixed item count, for callers caching variable-sized values where an
+// item-count cap gives no memory guarantee.
+type SizedLRU[K comparable, V any] struct {
+	mut sync.Mutex
+
+	maxCharge int64 // maximum total charge in cache
+	charge    int64 // current total charge in cache
+
+	sizeOf func(K, V) int64 // computes the charge of an entry
+
+	items syncmap.Map[K, *list.Element[sizedEntries[K, V]]] // map of items in cache
+
+	linked  *linked[sizedEntries[K, V]] // linked list of items in cache
+	evicted func(K, V)                  // callback function when an item is evicted
+
+	evictCh    chan struct{}                          // evict channel
+	recentlyCh chan *list.Element[sizedEntries[K, V]] // recently used channel
+	doneCh     chan struct{}                          // closed by Close to stop the background goroutines
+
+	isClosed uint32
+}
+
+// NewSizedLRU returns a new SizedLRU bounded by maxCharge. sizeOf computes
+// the charge of a key/value pair at Put time. Put adds the charge to the
+// running total and signals a background goroutine, which then evicts
+// least recently used entries until the total is within maxCharge; Put
+// does not wait for that, so the total can exceed maxCharge briefly.
+func NewSizedLRU[K comparable, V any](maxCharge int64, sizeOf func(K, V) int64, evicted func(K, V)) *SizedLRU[K, V] {
+	l := &SizedLRU[K, V]{
+		linked:     newLinked[sizedEntries[K, V]](),
+		maxCharge:  maxCharge,
+		sizeOf:     sizeOf,
+		evicted:    evicted,
+		evictCh:    make(chan struct{}, 1),
+		recentlyCh: make(chan *list.Element[sizedEntries[K, V]], 128),
+		doneCh:     make(chan struct{}),
+	}
+	go l.channelRecently()
+	go l.channelEvict()
+	return l
+}
+
+func (l *SizedLRU[K, V]) channelRecently() {
+	for {
+		select {
+		case item := <-l.recentlyCh:
+			l.linked.MoveToBack(item)
+		case <-l.doneCh:
+			return
+		}
+	}
+}
+
+func (l *SizedLRU[K, V]) channelEvict() {
+	for {
+		select {
+		case <-l.evictCh:
+			for l.Charge() > l.Cap() {
+				if _, _, evicted := l.Evict(); !evicted {
+					break
+				}
+			}
+		case <-l.doneCh:
+			return
+		}
+	}
+}
+
+func (l *SizedLRU[K, V]) toRecently(item *list.Element[sizedEntries[K, V]]) {
+	l.recentlyCh <- item
+}
+
+func (l *SizedLRU[K, V]) tryEvict() {
+	select {
+	case l.evictCh <- struct{}{}:
+	default:
+	}
+}
+
+// Evict evicts the least recently used item from the cache.
+func (l *SizedLRU[K, V]) Evict() (key K, value V, evicted bool) {
+	node := l.linked.Front()
+	if node == nil {
+		return
+	}
+
+	item := l.linked.Remove(node)
+	key, value = item.get()
+	l.items.Delete(key)
+	atomic.AddInt64(&l.charge, -item.charge)
+	if l.evicted != nil {
+		l.evicted(key, value)
+	}
+	return key, value, true
+}
+
+// Resize resizes the cache to the specified maximum charge.
+func (l *SizedLRU[K, V]) Resize(maxCharge int64) {
+	atomic.StoreInt64(&l.maxCharge, maxCharge)
+	l.tryEvict()
+}
+
+// Len returns the number of items in the cache.
+func (l *SizedLRU[K, V]) Len() int {
+	return l.linked.Len()
+}
+
+// Cap returns the maximum total charge of the cache.
+func (l *SizedLRU[K, V]) Cap() int64 {
+	return atomic.LoadInt64(&l.maxCharge)
+}
+
+// Charge returns the current total charge of the cache.
+func (l *SizedLRU[K, V]) Charge() int64 {
+	return atomic.LoadInt64(&l.charge)
+}
+
+// Put sets the value for the specified key. If the entry's own charge
+// exceeds the cache's maximum charge, Put rejects it and returns false.
+func (l *SizedLRU[K, V]) Put(key K, value V) (prev V, replaced bool) {
+	charge := l.sizeOf(key, value)
+	if charge > l.Cap() {
+		return prev, false
+	}
+
+	item, ok := l.items.Load(key)
+	if ok && item != nil {
+		l.toRecently(item)
+		var prevCharge int64
+		prev, prevCharge = item.Value.setCharge(value, charge)
+		atomic.AddInt64(&l.charge, charge-prevCharge)
+		l.tryEvict()
+		return prev, true
+	}
+
+	l.mut.Lock()
+	defer l.mut.Unlock()
+	item, ok = l.items.Load(key)
+	if ok && item != nil {
+		l.toRecently(item)
+		var prevCharge int64
+		prev, prevCharge = item.Value.setCharge(value, charge)
+		atomic.AddInt64(&l.charge, charge-prevCharge)
+		l.tryEvict()
+		return prev, true
+	}
+
+	item = l.linked.PushBack(sizedEntries[K, V]{entries: entries[K, V]{key: key, value: value}, charge: charge})
+	l.items.Store(key, item)
+	atomic.AddInt64(&l.charge, charge)
+
+	l.tryEvict()
+	return prev, false
+}
+
+// Get returns a value for key and mark it as most recently used.
+func (l *SizedLRU[K, V]) Get(key K) (value V, ok bool) {
+	item, ok := l.items.Load(key)
+	if !ok || item == nil {
+		return value, false
+	}
+
+	l.toRecently(item)
+	_, value = item.Value.get()
+	return value, true
+}
+
+// Contains returns true if the key exists.
+func (l *SizedLRU[K, V]) Contains(key K) bool {
+	_, ok := l.items.Load(key)
+	return ok
+}
+
+// Peek returns the value for key without marking it as most recently used.
+func (l *SizedLRU[K, V]) Peek(key K) (value V, ok bool) {
+	item, ok := l.items.Load(key)
+	if !ok || item == nil {
+		return value, false
+	}
+
+	_, value = item.Value.get()
+	return value, true
+}
+
+// Delete a value for a key
+func (l *SizedLRU[K, V]) Delete(key K) (prev V, deleted bool) {
+	item, ok := l.items.LoadAndDelete(key)
+	if !ok || item == nil {
+		return prev, false
+	}
+
+	l.linked.Remove(item)
+	atomic.AddInt64(&l.charge, -item.Value.charge)
+	_, prev = item.Value.get()
+	return prev, true
+}
+
+// ForEach iterates over the cache, calling f for each item.
+func (l *SizedLRU[K, V]) ForEach(iter func(key K, value V) bool) {
+	l.linked.ForEach(func(item *list.Element[sizedEntries[K, V]]) bool {
+		key, value := item.Value.get()
+		return iter(key, value)
+	})
+}
+
+// Keys returns a slice of the keys in the cache.
+func (l *SizedLRU[K, V]) Keys() []K {
+	keys := make([]K, 0, l.Len())
+	l.ForEach(func(key K, value V) bool {
+		keys = append(keys, key)
+		return true
+	})
+	return keys
+}
+
+// Values returns a slice of the values in the cache.
+func (l *SizedLRU[K, V]) Values() []V {
+	values := make([]V, 0, l.Len())
+	l.ForEach(func(key K, value V) bool {
+		values = append(values, value)
+		return true
+	})
+	return values
+}
+
+// Close stops the cache's background goroutines. Calls after the first
+// are no-ops.
+func (l *SizedLRU[K, V]) Close() {
+	if !atomic.CompareAndSwapUint32(&l.isClosed, 0, 1) {
+		return
+	}
+	close(l.doneCh)
+}
@@ -0,0 +1,47 @@
+package lru
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSizedLRU(t *testing.T) {
+	sized := NewSizedLRU[string, string](10, func(k, v string) int64 {
+		return int64(len(v))
+	}, func(k, v string) {
+		t.Logf("evict key: %s, value: %s", k, v)
+	})
+	defer sized.Close()
+
+	sized.Put("a", "12345") // charge 5
+	sized.Put("b", "12345") // charge 5, total 10
+	time.Sleep(time.Millisecond)
+	if c := sized.Charge(); c != 10 {
+		t.Errorf("got charge = %d, want 10", c)
+	}
+
+	// pushes total charge to 13, over the cap of 10, so "a" (LRU) is evicted.
+	sized.Put("c", "123")
+	time.Sleep(time.Millisecond)
+	if sized.Contains("a") {
+		t.Errorf("a should have been evicted to stay under the charge cap")
+	}
+	if c := sized.Charge(); c != 8 {
+		t.Errorf("got charge = %d, want 8", c)
+	}
+
+	// an entry whose own charge exceeds the cap is rejected outright.
+	if _, ok := sized.Put("too-big", "01234567890123"); ok {
+		t.Errorf("Put should reject an entry whose charge exceeds the cap")
+	}
+	if sized.Contains("too-big") {
+		t.Errorf("too-big should not be in the cache")
+	}
+
+	if _, ok := sized.Delete("b"); !ok {
+		t.Errorf("b should have been deleted")
+	}
+	if c := sized.Charge(); c != 3 {
+		t.Errorf("got charge = %d, want 3", c)
+	}
+}
@@ -0,0 +1,97 @@
+package lru
+
+import "sync/atomic"
+
+// EvictReason identifies why an entry left the cache, passed to the
+// eviction callback configured via NewLRUWithReason.
+type EvictReason uint8
+
+const (
+	// EvictCapacity means the entry was removed to keep the cache within
+	// its size limit.
+	EvictCapacity EvictReason = iota
+	// EvictExpired means the entry's TTL deadline had passed.
+	EvictExpired
+	// EvictManual means the entry was removed by an explicit call to Evict.
+	EvictManual
+	// EvictResize means the entry was removed because Resize shrank the
+	// cache below its current length.
+	EvictResize
+)
+
+// String returns a short human-readable name for the reason.
+func (r EvictReason) String() string {
+	switch r {
+	case EvictCapacity:
+		return "capacity"
+	case EvictExpired:
+		return "expired"
+	case EvictManual:
+		return "manual"
+	case EvictResize:
+		return "resize"
+	default:
+		return "unknown"
+	}
+}
+
+// Stats is a snapshot of an LRU's running counters. Fetch one with
+// (*LRU[K, V]).Stats; it is safe to poll from a metrics goroutine since
+// every counter is updated with atomic operations on the hot path.
+type Stats struct {
+	Hits        uint64
+	Misses      uint64
+	Evictions   uint64
+	Expirations uint64
+	Insertions  uint64
+	Updates     uint64
+}
+
+// lruStats holds the counters backing Stats. LRU holds it by value in its
+// stats field so the counters live next to the cache they describe.
+type lruStats struct {
+	hits        uint64
+	misses      uint64
+	evictions   uint64
+	expirations uint64
+	insertions  uint64
+	updates     uint64
+}
+
+func (s *lruStats) snapshot() Stats {
+	return Stats{
+		Hits:        atomic.LoadUint64(&s.hits),
+		Misses:      atomic.LoadUint64(&s.misses),
+		Evictions:   atomic.LoadUint64(&s.evictions),
+		Expirations: atomic.LoadUint64(&s.expirations),
+		Insertions:  atomic.LoadUint64(&s.insertions),
+		Updates:     atomic.LoadUint64(&s.updates),
+	}
+}
+
+func (s *lruStats) reset() {
+	atomic.StoreUint64(&s.hits, 0)
+	atomic.StoreUint64(&s.misses, 0)
+	atomic.StoreUint64(&s.evictions, 0)
+	atomic.StoreUint64(&s.expirations, 0)
+	atomic.StoreUint64(&s.insertions, 0)
+	atomic.StoreUint64(&s.updates, 0)
+}
+
+func (s *lruStats) recordEvict(reason EvictReason) {
+	if reason == EvictExpired {
+		atomic.AddUint64(&s.expirations, 1)
+		return
+	}
+	atomic.AddUint64(&s.evictions, 1)
+}
+
+// Stats returns a snapshot of the cache's running counters.
+func (l *LRU[K, V]) Stats() Stats {
+	return l.stats.snapshot()
+}
+
+// ResetStats zeroes the cache's running counters.
+func (l *LRU[K, V]) ResetStats() {
+	l.stats.reset()
+}
@@ -0,0 +1,60 @@
+package lru
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLRUStats(t *testing.T) {
+	var reasons []EvictReason
+	lru := NewLRUWithReason[string, string](2, func(k, v string, reason EvictReason) {
+		reasons = append(reasons, reason)
+	})
+	defer lru.Close()
+
+	lru.Put("a", "1")
+	lru.Put("b", "2")
+	lru.Put("a", "1-again")
+	lru.Get("a")
+	lru.Get("missing")
+
+	time.Sleep(time.Millisecond)
+	stats := lru.Stats()
+	if stats.Insertions != 2 {
+		t.Errorf("got insertions = %d, want 2", stats.Insertions)
+	}
+	if stats.Updates != 1 {
+		t.Errorf("got updates = %d, want 1", stats.Updates)
+	}
+	if stats.Hits != 1 {
+		t.Errorf("got hits = %d, want 1", stats.Hits)
+	}
+	if stats.Misses != 1 {
+		t.Errorf("got misses = %d, want 1", stats.Misses)
+	}
+
+	lru.Put("c", "3") // over capacity, evicts the LRU entry
+	time.Sleep(time.Millisecond)
+	stats = lru.Stats()
+	if stats.Evictions != 1 {
+		t.Errorf("got evictions = %d, want 1", stats.Evictions)
+	}
+	if len(reasons) != 1 || reasons[0] != EvictCapacity {
+		t.Errorf("got reasons = %v, want [%v]", reasons, EvictCapacity)
+	}
+
+	lru.Evict()
+	stats = lru.Stats()
+	if stats.Evictions != 2 {
+		t.Errorf("got evictions = %d, want 2", stats.Evictions)
+	}
+	if len(reasons) != 2 || reasons[1] != EvictManual {
+		t.Errorf("got reasons = %v, want last entry %v", reasons, EvictManual)
+	}
+
+	lru.ResetStats()
+	stats = lru.Stats()
+	if stats != (Stats{}) {
+		t.Errorf("got stats = %+v after ResetStats, want zero value", stats)
+	}
+}
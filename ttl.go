@@ -0,0 +1,128 @@
+package lru
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/wzshiming/lru/internal/timingwheel"
+)
+
+const (
+	// defaultTickInterval is the wheel's tick granularity. The wheel's
+	// active sweep fires no earlier than an entry's deadline and up to one
+	// tick plus the scheduling jitter (up to 10% of the TTL) after it; a
+	// read past the deadline treats the entry as missing at once.
+	defaultTickInterval = time.Second
+	// defaultWheelSlots is the number of slots in a single wheel
+	// revolution (one hour at a 1s tick); TTLs longer than that wait out
+	// the extra revolutions rather than being bound by it.
+	defaultWheelSlots = 3600
+)
+
+// NewLRUWithTTL returns a new LRU of the given size whose entries expire
+// defaultTTL after being set, unless overridden per-key with PutWithTTL.
+// Expiration is enforced lazily (a Get/Peek/Contains past an entry's
+// deadline treats it as a miss) and actively by a background hashed
+// timing wheel, so an idle expired entry doesn't linger until something
+// happens to read it.
+func NewLRUWithTTL[K comparable, V any](size int, defaultTTL time.Duration, evicted func(K, V)) *LRU[K, V] {
+	l := NewLRU[K, V](size, evicted)
+	l.defaultTTL = defaultTTL
+	l.wheel = timingwheel.New[K](defaultTickInterval, defaultWheelSlots, l.onExpire)
+	l.wheel.Start()
+	return l
+}
+
+// PutWithTTL sets the value for the specified key with a per-entry TTL,
+// overriding the cache's default. A zero ttl means the entry never
+// expires.
+func (l *LRU[K, V]) PutWithTTL(key K, value V, ttl time.Duration) (prev V, replaced bool) {
+	l.mut.Lock()
+
+	var expiresAt int64
+	if ttl > 0 {
+		expiresAt = time.Now().Add(ttl).UnixNano()
+	}
+
+	l.pendingReason = EvictCapacity
+	wrapped, replaced := l.core.Put(key, ttlValue[V]{value: value, expiresAt: expiresAt})
+	if replaced {
+		atomic.AddUint64(&l.stats.updates, 1)
+	} else {
+		atomic.AddUint64(&l.stats.insertions, 1)
+	}
+	l.scheduleExpiry(key, ttl)
+	l.mut.Unlock()
+
+	return wrapped.value, replaced
+}
+
+// Expire updates the TTL of an existing key without changing its value or
+// its recency. As with PutWithTTL, a zero or negative ttl means the entry
+// never expires. It reports whether the key was present.
+func (l *LRU[K, V]) Expire(key K, ttl time.Duration) bool {
+	l.mut.Lock()
+	defer l.mut.Unlock()
+
+	node, ok := l.core.items[key]
+	if !ok {
+		return false
+	}
+	var expiresAt int64
+	if ttl > 0 {
+		expiresAt = time.Now().Add(ttl).UnixNano()
+	}
+	node.value.expiresAt = expiresAt
+	l.scheduleExpiry(key, ttl)
+	return true
+}
+
+// scheduleExpiry arranges for the wheel to sweep key after ttl, falling
+// back to the cache's default TTL. It is a no-op for caches created
+// without TTL support. Callers must hold l.mut.
+func (l *LRU[K, V]) scheduleExpiry(key K, ttl time.Duration) {
+	if l.wheel == nil {
+		return
+	}
+	if ttl <= 0 {
+		ttl = l.defaultTTL
+	}
+	if ttl > 0 {
+		l.wheel.Schedule(key, ttl)
+	}
+}
+
+// isExpired reports whether node's deadline has passed. Callers must hold
+// l.mut.
+func (l *LRU[K, V]) isExpired(node *basicNode[K, ttlValue[V]]) bool {
+	return node.value.expiresAt != 0 && time.Now().UnixNano() >= node.value.expiresAt
+}
+
+// removeExpiredNode removes a node found past its deadline and runs the
+// eviction callback for it with EvictExpired. Callers must hold l.mut.
+func (l *LRU[K, V]) removeExpiredNode(key K, node *basicNode[K, ttlValue[V]]) {
+	l.core.unlink(node)
+	delete(l.core.items, key)
+	if l.wheel != nil {
+		l.wheel.Remove(key)
+	}
+	l.stats.recordEvict(EvictExpired)
+	if l.userEvicted != nil {
+		l.userEvicted(key, node.value.value, EvictExpired)
+	}
+}
+
+// onExpire is invoked by the timing wheel when a key's scheduled tick
+// fires. It double-checks the entry is still actually expired before
+// removing it, since a later Get/Put/Expire may have refreshed its
+// deadline after it was scheduled.
+func (l *LRU[K, V]) onExpire(key K) {
+	l.mut.Lock()
+	defer l.mut.Unlock()
+
+	node, ok := l.core.items[key]
+	if !ok || !l.isExpired(node) {
+		return
+	}
+	l.removeExpiredNode(key, node)
+}
@@ -0,0 +1,53 @@
+package lru
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLRUWithTTL(t *testing.T) {
+	var evictedKey string
+	lru := NewLRUWithTTL[string, string](4, 20*time.Millisecond, func(k, v string) {
+		evictedKey = k
+	})
+	defer lru.Close()
+
+	lru.Put("key1", "value1")
+	if v, ok := lru.Get("key1"); !ok || v != "value1" {
+		t.Errorf("got get(key1) = %q, %v, want value1, true", v, ok)
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	if _, ok := lru.Get("key1"); ok {
+		t.Errorf("key1 should have expired")
+	}
+	if evictedKey != "key1" {
+		t.Errorf("got evicted key = %q, want key1", evictedKey)
+	}
+
+	lru.PutWithTTL("key2", "value2", time.Hour)
+	if !lru.Contains("key2") {
+		t.Errorf("key2 should still be live")
+	}
+
+	if !lru.Expire("key2", time.Millisecond) {
+		t.Errorf("Expire should report key2 as present")
+	}
+	time.Sleep(5 * time.Millisecond)
+	if lru.Contains("key2") {
+		t.Errorf("key2 should have expired after Expire")
+	}
+
+	if lru.Expire("missing", time.Second) {
+		t.Errorf("Expire should report missing key as absent")
+	}
+
+	lru.PutWithTTL("key3", "value3", time.Millisecond)
+	if !lru.Expire("key3", 0) {
+		t.Errorf("Expire should report key3 as present")
+	}
+	time.Sleep(5 * time.Millisecond)
+	if !lru.Contains("key3") {
+		t.Errorf("Expire(key, 0) should make key3 permanent, like PutWithTTL's zero ttl")
+	}
+}